@@ -0,0 +1,99 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/byte/gohttpprobe/internal/probe"
+	"github.com/spf13/cobra"
+)
+
+// newTestFlagCmd returns a *cobra.Command with every flag applyProfileDefaults
+// checks via cmd.Flags().Changed registered, so tests can mark individual
+// flags as explicitly set without depending on the real rootCmd's global
+// flag state.
+func newTestFlagCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("url", "", "")
+	cmd.Flags().String("input", "", "")
+	cmd.Flags().Int("concurrent", 0, "")
+	cmd.Flags().StringArray("header", nil, "")
+	cmd.Flags().String("cookies", "", "")
+	cmd.Flags().String("proxy", "", "")
+	cmd.Flags().String("methods", "", "")
+	cmd.Flags().Bool("safe-only", false, "")
+	cmd.Flags().Bool("insecure", false, "")
+	cmd.Flags().Bool("follow", false, "")
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().String("cookie-jar", "", "")
+	cmd.Flags().Int("retries", 0, "")
+	cmd.Flags().Int("retry-delay", 0, "")
+	cmd.Flags().Int("retry-max-delay", 0, "")
+	cmd.Flags().String("retry-on", "", "")
+	return cmd
+}
+
+func TestApplyProfileDefaultsPrecedence(t *testing.T) {
+	testCases := []struct {
+		name        string
+		flag        string
+		setFlag     func(cmd *cobra.Command)
+		cliConfig   probe.Config
+		fileConfig  probe.Config
+		wantChanged func(result probe.Config) any
+		wantValue   any
+	}{
+		{
+			name: "explicit CLI flag wins over profile value",
+			flag: "cookies",
+			setFlag: func(cmd *cobra.Command) {
+				_ = cmd.Flags().Set("cookies", "cli-value")
+			},
+			cliConfig:   probe.Config{Cookies: "cli-value"},
+			fileConfig:  probe.Config{Cookies: "profile-value"},
+			wantChanged: func(result probe.Config) any { return result.Cookies },
+			wantValue:   "cli-value",
+		},
+		{
+			name:        "profile value applies when flag is untouched",
+			flag:        "cookies",
+			setFlag:     func(cmd *cobra.Command) {},
+			cliConfig:   probe.Config{Cookies: ""},
+			fileConfig:  probe.Config{Cookies: "profile-value"},
+			wantChanged: func(result probe.Config) any { return result.Cookies },
+			wantValue:   "profile-value",
+		},
+		{
+			name: "explicit CLI concurrent flag wins over profile value",
+			flag: "concurrent",
+			setFlag: func(cmd *cobra.Command) {
+				_ = cmd.Flags().Set("concurrent", "7")
+			},
+			cliConfig:   probe.Config{Threads: 7},
+			fileConfig:  probe.Config{Threads: 20},
+			wantChanged: func(result probe.Config) any { return result.Threads },
+			wantValue:   7,
+		},
+		{
+			name:        "profile concurrent applies when flag is untouched",
+			flag:        "concurrent",
+			setFlag:     func(cmd *cobra.Command) {},
+			cliConfig:   probe.Config{Threads: 0},
+			fileConfig:  probe.Config{Threads: 20},
+			wantChanged: func(result probe.Config) any { return result.Threads },
+			wantValue:   20,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTestFlagCmd()
+			tc.setFlag(cmd)
+
+			result := applyProfileDefaults(tc.cliConfig, tc.fileConfig, cmd)
+
+			if got := tc.wantChanged(result); got != tc.wantValue {
+				t.Errorf("Expected %s to be %v, got %v", tc.flag, tc.wantValue, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,36 @@ var flagCategories = []struct {
 			},
 		},
 	},
+	{
+		Name: "Configuration file",
+		Flags: []Flag{
+			StringFlag{
+				BaseFlag: BaseFlag{"config", "", "Load options from a YAML config file"},
+				Default:  "",
+				Target:   &configFile,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"profile", "", "Named profile to use from the config file"},
+				Default:  "",
+				Target:   &profile,
+			},
+		},
+	},
+	{
+		Name: "Recursive scanning",
+		Flags: []Flag{
+			BoolFlag{
+				BaseFlag: BaseFlag{"recursive", "", "Follow same-origin links discovered in responses"},
+				Default:  false,
+				Target:   &recursive,
+			},
+			IntFlag{
+				BaseFlag: BaseFlag{"max-depth", "", "Maximum link-following depth when --recursive is set (default: 2)"},
+				Default:  2,
+				Target:   &maxDepth,
+			},
+		},
+	},
 	{
 		Name: "Connection options",
 		Flags: []Flag{
@@ -47,6 +77,51 @@ var flagCategories = []struct {
 				Default:  10,
 				Target:   &timeout,
 			},
+			StringFlag{
+				BaseFlag: BaseFlag{"cert", "", "Client certificate file for mutual TLS authentication"},
+				Default:  "",
+				Target:   &clientCert,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"key", "", "Client private key file for mutual TLS authentication"},
+				Default:  "",
+				Target:   &clientKey,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"cacert", "", "CA certificate file to validate the server certificate against"},
+				Default:  "",
+				Target:   &caCert,
+			},
+			IntFlag{
+				BaseFlag: BaseFlag{"retries", "", "Number of retries for transient errors and configured status codes"},
+				Default:  0,
+				Target:   &maxRetries,
+			},
+			IntFlag{
+				BaseFlag: BaseFlag{"retry-delay", "", "Base delay in milliseconds between retries (default: 500)"},
+				Default:  500,
+				Target:   &retryDelayMs,
+			},
+			IntFlag{
+				BaseFlag: BaseFlag{"retry-max-delay", "", "Maximum delay in milliseconds between retries (default: 10000)"},
+				Default:  10000,
+				Target:   &retryMaxDelayMs,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"retry-on", "", "Comma-separated status codes to retry on (default: 429,502,503,504)"},
+				Default:  "",
+				Target:   &retryOn,
+			},
+			IntFlag{
+				BaseFlag: BaseFlag{"rate-limit", "", "Limit requests per second across all methods tested (default: unlimited)"},
+				Default:  0,
+				Target:   &rateLimit,
+			},
+			BoolFlag{
+				BaseFlag: BaseFlag{"backoff", "", "Halve the rate limit whenever a retryable status code is seen"},
+				Default:  false,
+				Target:   &backoff,
+			},
 		},
 	},
 	{
@@ -86,6 +161,26 @@ var flagCategories = []struct {
 				Default:  "",
 				Target:   &wordlist,
 			},
+			StringFlag{
+				BaseFlag: BaseFlag{"cors", "", "Probe CORS preflight behavior using the given Origin value"},
+				Default:  "",
+				Target:   &corsOrigin,
+			},
+			BoolFlag{
+				BaseFlag: BaseFlag{"bypass", "", "Try known 403/401 bypass header and path mutations against each method"},
+				Default:  false,
+				Target:   &bypassMode,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"bypass-ip", "", "Spoofed IP to use in bypass header mutations (default: 127.0.0.1)"},
+				Default:  "127.0.0.1",
+				Target:   &bypassIP,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"bypass-payloads", "", "Load additional bypass mutations from a YAML/JSON file"},
+				Default:  "",
+				Target:   &bypassPayloads,
+			},
 		},
 	},
 	{
@@ -106,6 +201,36 @@ var flagCategories = []struct {
 				Default:  "",
 				Target:   &jsonFile,
 			},
+			BoolFlag{
+				BaseFlag: BaseFlag{"curl", "", "Print an equivalent curl command for each probed method"},
+				Default:  false,
+				Target:   &curl,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"export-curl", "", "Write a reproducible curl command for each probed method to the given file"},
+				Default:  "",
+				Target:   &exportCurl,
+			},
+			BoolFlag{
+				BaseFlag: BaseFlag{"trace", "", "Show per-method DNS/connect/TLS/TTFB timings"},
+				Default:  false,
+				Target:   &trace,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"dump", "", "Write the full raw request/response transaction for each method to the given file"},
+				Default:  "",
+				Target:   &dumpFile,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"log-file", "", "Write log output to the given file instead of stdout"},
+				Default:  "",
+				Target:   &logFile,
+			},
+			StringFlag{
+				BaseFlag: BaseFlag{"log-format", "", "Log output format: \"text\" (default) or \"json\""},
+				Default:  "",
+				Target:   &logFormat,
+			},
 		},
 	},
 }
@@ -2,7 +2,9 @@ package app
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/byte/gohttpprobe/internal/probe"
 	"github.com/spf13/cobra"
@@ -35,6 +37,44 @@ var (
 				InputFile:   inputFile,
 				CookieJar:   cookieJar,
 				Timeout:     timeout,
+				Curl:        curl,
+				Trace:       trace,
+				ExportCurl:  exportCurl,
+
+				MaxRetries:     maxRetries,
+				RetryBaseDelay: time.Duration(retryDelayMs) * time.Millisecond,
+				RetryMaxDelay:  time.Duration(retryMaxDelayMs) * time.Millisecond,
+				RetryOnStatus:  parseRetryStatuses(retryOn),
+
+				CORSOrigin: corsOrigin,
+
+				ClientCert: clientCert,
+				ClientKey:  clientKey,
+				CACert:     caCert,
+
+				Recursive: recursive,
+				MaxDepth:  maxDepth,
+
+				BypassMode:     bypassMode,
+				BypassIP:       bypassIP,
+				BypassPayloads: bypassPayloads,
+
+				DumpFile: dumpFile,
+
+				LogFile:   logFile,
+				LogFormat: logFormat,
+
+				RateLimit: rateLimit,
+				Backoff:   backoff,
+			}
+
+			// Layer in a config file profile for any flag the user didn't set explicitly
+			if configFile != "" {
+				fileConfig, err := probe.LoadConfig(configFile, profile)
+				if err != nil {
+					return fmt.Errorf("failed to load config file: %w", err)
+				}
+				config = applyProfileDefaults(config, fileConfig, cmd)
 			}
 
 			// Run the probe
@@ -59,6 +99,38 @@ var (
 	inputFile   string
 	cookieJar   string
 	timeout     int
+	curl        bool
+	trace       bool
+	exportCurl  string
+
+	maxRetries      int
+	retryDelayMs    int
+	retryMaxDelayMs int
+	retryOn         string
+
+	configFile string
+	profile    string
+
+	corsOrigin string
+
+	clientCert string
+	clientKey  string
+	caCert     string
+
+	recursive bool
+	maxDepth  int
+
+	bypassMode     bool
+	bypassIP       string
+	bypassPayloads string
+
+	dumpFile string
+
+	logFile   string
+	logFormat string
+
+	rateLimit int
+	backoff   bool
 )
 
 // Execute executes the root command.
@@ -119,6 +191,82 @@ func help(cmd *cobra.Command, args []string) {
 	}
 }
 
+// applyProfileDefaults layers fileConfig onto cliConfig, but only for options
+// whose flag the user did not set explicitly on the command line - explicit
+// CLI flags always win over the config file profile.
+func applyProfileDefaults(cliConfig, fileConfig probe.Config, cmd *cobra.Command) probe.Config {
+	result := cliConfig
+	changed := cmd.Flags().Changed
+
+	if !changed("url") && fileConfig.URL != "" {
+		result.URL = fileConfig.URL
+	}
+	if !changed("input") && fileConfig.InputFile != "" {
+		result.InputFile = fileConfig.InputFile
+	}
+	if !changed("concurrent") && fileConfig.Threads != 0 {
+		result.Threads = fileConfig.Threads
+	}
+	if !changed("header") && len(fileConfig.Headers) > 0 {
+		result.Headers = fileConfig.Headers
+	}
+	if !changed("cookies") && fileConfig.Cookies != "" {
+		result.Cookies = fileConfig.Cookies
+	}
+	if !changed("proxy") && fileConfig.Proxy != "" {
+		result.Proxy = fileConfig.Proxy
+	}
+	if !changed("methods") && fileConfig.Wordlist != "" {
+		result.Wordlist = fileConfig.Wordlist
+	}
+	if !changed("safe-only") && fileConfig.SafeOnly {
+		result.SafeOnly = fileConfig.SafeOnly
+	}
+	if !changed("insecure") && fileConfig.Insecure {
+		result.Insecure = fileConfig.Insecure
+	}
+	if !changed("follow") && fileConfig.FollowRedir {
+		result.FollowRedir = fileConfig.FollowRedir
+	}
+	if !changed("output") && fileConfig.JSONFile != "" {
+		result.JSONFile = fileConfig.JSONFile
+	}
+	if !changed("cookie-jar") && fileConfig.CookieJar != "" {
+		result.CookieJar = fileConfig.CookieJar
+	}
+	if !changed("retries") && fileConfig.MaxRetries != 0 {
+		result.MaxRetries = fileConfig.MaxRetries
+	}
+	if !changed("retry-delay") && fileConfig.RetryBaseDelay != 0 {
+		result.RetryBaseDelay = fileConfig.RetryBaseDelay
+	}
+	if !changed("retry-max-delay") && fileConfig.RetryMaxDelay != 0 {
+		result.RetryMaxDelay = fileConfig.RetryMaxDelay
+	}
+	if !changed("retry-on") && len(fileConfig.RetryOnStatus) > 0 {
+		result.RetryOnStatus = fileConfig.RetryOnStatus
+	}
+
+	return result
+}
+
+// parseRetryStatuses parses a comma-separated list of HTTP status codes
+// (e.g. "429,502,503,504") into a slice of ints, skipping any entries that
+// don't parse as integers.
+func parseRetryStatuses(statuses string) []int {
+	var codes []int
+	for _, part := range strings.Split(statuses, ",") {
+		trimmed := strings.TrimSpace(part)
+		if trimmed == "" {
+			continue
+		}
+		if code, err := strconv.Atoi(trimmed); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
 func normalizeHeaderFlags(headers []string) []string {
 	var normalized []string
 	for _, h := range headers {
@@ -0,0 +1,251 @@
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultBypassIP is used for spoofed-origin header mutations when
+// config.BypassIP is not set.
+const defaultBypassIP = "127.0.0.1"
+
+// bypassContext carries the per-request values a bypassMutation's header
+// value may depend on: the configured spoof IP, the untouched target URL and
+// path, and the HTTP method actually under test (method-override mutations
+// send a different verb on the wire and need to recover this for the header
+// value).
+type bypassContext struct {
+	BypassIP  string
+	TargetURL string
+	Path      string
+	Method    string
+}
+
+// bypassMutation describes a single well-known 403/401 bypass permutation:
+// a spoofed-origin or method-override header, a path mutation, or both, in
+// the spirit of tools like nomore403/dontgo403.
+type bypassMutation struct {
+	id     string
+	header string
+	value  func(ctx bypassContext) string
+	path   func(path string) string
+	// method, when set, overrides the HTTP verb sent on the wire (used by
+	// method-override mutations, which send a safe base method like GET and
+	// rely on a header to carry the actual verb under test).
+	method string
+}
+
+// defaultBypassMutations is the built-in table of 403/401 bypass techniques.
+var defaultBypassMutations = []bypassMutation{
+	{id: "header:x-forwarded-for", header: "X-Forwarded-For", value: bypassIPValue},
+	{id: "header:x-forwarded-host", header: "X-Forwarded-Host", value: bypassIPValue},
+	{id: "header:x-originating-ip", header: "X-Originating-IP", value: bypassIPValue},
+	{id: "header:x-remote-ip", header: "X-Remote-IP", value: bypassIPValue},
+	{id: "header:x-remote-addr", header: "X-Remote-Addr", value: bypassIPValue},
+	{id: "header:x-client-ip", header: "X-Client-IP", value: bypassIPValue},
+	{id: "header:x-custom-ip-authorization", header: "X-Custom-IP-Authorization", value: bypassIPValue},
+	{id: "header:x-host", header: "X-Host", value: bypassIPValue},
+	{id: "header:x-original-url", header: "X-Original-URL", value: func(ctx bypassContext) string { return ctx.Path }},
+	{id: "header:x-rewrite-url", header: "X-Rewrite-URL", value: func(ctx bypassContext) string { return ctx.Path }},
+	{id: "header:referer", header: "Referer", value: func(ctx bypassContext) string { return ctx.TargetURL }},
+
+	// Method-override mutations send the safe GET verb on the wire and rely
+	// on the header to carry the method actually under test, so a front-line
+	// WAF filtering on the literal verb sees GET while a framework honoring
+	// the override header executes the restricted method.
+	{id: "header:x-http-method-override", header: "X-HTTP-Method-Override", value: methodOverrideValue, method: http.MethodGet},
+	{id: "header:x-method-override", header: "X-Method-Override", value: methodOverrideValue, method: http.MethodGet},
+	{id: "header:x-http-method", header: "X-HTTP-Method", value: methodOverrideValue, method: http.MethodGet},
+
+	{id: "path:trailing-slash", path: func(p string) string { return p + "/" }},
+	{id: "path:trailing-dot", path: func(p string) string { return p + "/." }},
+	{id: "path:double-slash", path: func(p string) string { return "//" + strings.TrimPrefix(p, "/") }},
+	{id: "path:dot-segment", path: func(p string) string { return p + "/./" }},
+	{id: "path:encoded-dot", path: func(p string) string { return p + "/%2e/" }},
+	{id: "path:encoded-space", path: func(p string) string { return p + "/%20" }},
+	{id: "path:semicolon-bypass", path: func(p string) string { return p + "/..;/" }},
+	{id: "path:semicolon", path: func(p string) string { return p + "/;/" }},
+	{id: "path:trailing-hash", path: func(p string) string { return p + "#" }},
+	{id: "path:trailing-question", path: func(p string) string { return p + "?" }},
+	{id: "path:uppercase", path: strings.ToUpper},
+	{id: "path:encoded-last-segment", path: encodeLastSegment},
+}
+
+// bypassIPValue returns ctx.BypassIP, falling back to defaultBypassIP.
+func bypassIPValue(ctx bypassContext) string {
+	if ctx.BypassIP == "" {
+		return defaultBypassIP
+	}
+	return ctx.BypassIP
+}
+
+// methodOverrideValue returns the HTTP method actually under test, to be
+// carried in a method-override header while the real request is sent as a
+// safe base method.
+func methodOverrideValue(ctx bypassContext) string {
+	return ctx.Method
+}
+
+// encodeLastSegment percent-encodes every byte of a path's final segment,
+// leaving the rest of the path untouched.
+func encodeLastSegment(p string) string {
+	idx := strings.LastIndex(p, "/")
+	prefix, last := p[:idx+1], p[idx+1:]
+	if last == "" {
+		return p
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(last); i++ {
+		fmt.Fprintf(&b, "%%%02X", last[i])
+	}
+	return prefix + b.String()
+}
+
+// bypassPayload is the on-disk shape of a single user-supplied mutation
+// loaded via --bypass-payloads, in either YAML or JSON. Unlike the built-in
+// table, a payload's header value and path rewrite are both static strings,
+// since file-based mutations can't carry Go closures.
+type bypassPayload struct {
+	ID         string `yaml:"id" json:"id"`
+	Header     string `yaml:"header" json:"header"`
+	Value      string `yaml:"value" json:"value"`
+	Method     string `yaml:"method" json:"method"`
+	PathPrefix string `yaml:"path_prefix" json:"path_prefix"`
+	PathSuffix string `yaml:"path_suffix" json:"path_suffix"`
+	Uppercase  bool   `yaml:"uppercase" json:"uppercase"`
+}
+
+// loadBypassMutations returns the built-in bypass mutation table, extended
+// with any additional mutations described in payloadsFile (YAML by default,
+// or JSON if the file has a .json extension). An empty payloadsFile returns
+// just the built-in table.
+func loadBypassMutations(payloadsFile string) ([]bypassMutation, error) {
+	mutations := append([]bypassMutation(nil), defaultBypassMutations...)
+	if payloadsFile == "" {
+		return mutations, nil
+	}
+
+	extra, err := readBypassPayloadFile(payloadsFile)
+	if err != nil {
+		return nil, err
+	}
+	return append(mutations, extra...), nil
+}
+
+// readBypassPayloadFile parses a user-supplied bypass mutation file into
+// bypassMutations.
+func readBypassPayloadFile(path string) ([]bypassMutation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bypass payloads file: %w", err)
+	}
+
+	var payloads []bypassPayload
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		err = json.Unmarshal(data, &payloads)
+	} else {
+		err = yaml.Unmarshal(data, &payloads)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bypass payloads file: %w", err)
+	}
+
+	mutations := make([]bypassMutation, 0, len(payloads))
+	for _, p := range payloads {
+		mutation := bypassMutation{id: p.ID, header: p.Header, method: p.Method}
+
+		if p.Header != "" {
+			value := p.Value
+			mutation.value = func(bypassContext) string { return value }
+		}
+
+		if p.PathPrefix != "" || p.PathSuffix != "" || p.Uppercase {
+			prefix, suffix, upper := p.PathPrefix, p.PathSuffix, p.Uppercase
+			mutation.path = func(path string) string {
+				if upper {
+					path = strings.ToUpper(path)
+				}
+				return prefix + path + suffix
+			}
+		}
+
+		mutations = append(mutations, mutation)
+	}
+	return mutations, nil
+}
+
+// probeBypasses replays method against every mutation in mutations and
+// returns the ones whose status code or body length differ from baseline,
+// keyed by mutation ID, so only "interesting" results are kept.
+func probeBypasses(client *http.Client, config Config, method string, headers http.Header, baseline Result, mutations []bypassMutation) map[string]BypassResult {
+	base, err := url.Parse(config.URL)
+	if err != nil {
+		return nil
+	}
+
+	ctx := bypassContext{
+		BypassIP:  config.BypassIP,
+		TargetURL: config.URL,
+		Path:      base.Path,
+		Method:    method,
+	}
+
+	interesting := make(map[string]BypassResult)
+	for _, mutation := range mutations {
+		targetURL := config.URL
+		if mutation.path != nil {
+			mutated := *base
+			mutatedPath := mutation.path(base.Path)
+			mutated.Path, mutated.RawPath = mutatedPath, ""
+			// Keep percent-escapes added by the mutation (e.g. %2e) as-is on
+			// the wire instead of letting String() re-escape them to %252e.
+			if decoded, err := url.PathUnescape(mutatedPath); err == nil {
+				mutated.Path, mutated.RawPath = decoded, mutatedPath
+			}
+			targetURL = mutated.String()
+		}
+
+		reqMethod := method
+		if mutation.method != "" {
+			reqMethod = mutation.method
+		}
+
+		req, err := newMethodRequest(reqMethod, targetURL, headers)
+		if err != nil {
+			continue
+		}
+		if mutation.header != "" && mutation.value != nil {
+			req.Header.Set(mutation.header, mutation.value(ctx))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+		_ = resp.Body.Close()
+
+		if resp.StatusCode == baseline.StatusCode && len(body) == baseline.Length {
+			continue
+		}
+
+		interesting[mutation.id] = BypassResult{
+			StatusCode: resp.StatusCode,
+			Length:     len(body),
+			Reason:     resp.Status,
+		}
+	}
+
+	if len(interesting) == 0 {
+		return nil
+	}
+	return interesting
+}
@@ -0,0 +1,88 @@
+package probe
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the on-disk shape of a gohttpprobe YAML config file: a named
+// set of profiles, each describing the same options as Config.
+type fileConfig struct {
+	Profiles map[string]profileConfig `yaml:"profiles"`
+}
+
+// profileConfig mirrors the subset of Config fields that can be set from a
+// config file profile.
+type profileConfig struct {
+	URL              string   `yaml:"url"`
+	InputFile        string   `yaml:"input_file"`
+	Threads          int      `yaml:"threads"`
+	Headers          []string `yaml:"headers"`
+	Cookies          string   `yaml:"cookies"`
+	Proxy            string   `yaml:"proxy"`
+	Wordlist         string   `yaml:"methods"`
+	SafeOnly         bool     `yaml:"safe_only"`
+	Insecure         bool     `yaml:"insecure"`
+	FollowRedir      bool     `yaml:"follow_redirects"`
+	JSONFile         string   `yaml:"output"`
+	CookieJar        string   `yaml:"cookie_jar"`
+	MaxRetries       int      `yaml:"max_retries"`
+	RetryBaseDelayMS int      `yaml:"retry_base_delay_ms"`
+	RetryMaxDelayMS  int      `yaml:"retry_max_delay_ms"`
+	RetryOnStatus    []int    `yaml:"retry_on_status"`
+}
+
+// LoadConfig reads a YAML config file and returns the Config described by
+// the named profile. If profile is empty, the "default" profile is used
+// when present; otherwise the file must define exactly one profile.
+func LoadConfig(path, profile string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var parsed fileConfig
+	if err = yaml.Unmarshal(data, &parsed); err != nil {
+		return Config{}, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	name := profile
+	if name == "" {
+		if _, ok := parsed.Profiles["default"]; ok {
+			name = "default"
+		} else if len(parsed.Profiles) == 1 {
+			for only := range parsed.Profiles {
+				name = only
+			}
+		} else {
+			return Config{}, fmt.Errorf("config file defines %d profiles; specify one with --profile", len(parsed.Profiles))
+		}
+	}
+
+	selected, ok := parsed.Profiles[name]
+	if !ok {
+		return Config{}, fmt.Errorf("profile %q not found in config file", name)
+	}
+
+	return Config{
+		URL:            selected.URL,
+		InputFile:      selected.InputFile,
+		Threads:        selected.Threads,
+		Headers:        selected.Headers,
+		Cookies:        selected.Cookies,
+		Proxy:          selected.Proxy,
+		Wordlist:       selected.Wordlist,
+		SafeOnly:       selected.SafeOnly,
+		Insecure:       selected.Insecure,
+		FollowRedir:    selected.FollowRedir,
+		JSONFile:       selected.JSONFile,
+		CookieJar:      selected.CookieJar,
+		MaxRetries:     selected.MaxRetries,
+		RetryBaseDelay: time.Duration(selected.RetryBaseDelayMS) * time.Millisecond,
+		RetryMaxDelay:  time.Duration(selected.RetryMaxDelayMS) * time.Millisecond,
+		RetryOnStatus:  selected.RetryOnStatus,
+	}, nil
+}
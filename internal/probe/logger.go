@@ -1,13 +1,22 @@
 package probe
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"time"
 )
 
-// Logger structure with configuration options
+// Logger structure with configuration options. By default it renders
+// human-readable lines to stdout; setting Output and/or JSON reroutes
+// entries to a different sink (e.g. a file) and/or renders them as
+// JSON lines suitable for jq or a log processor.
 type Logger struct {
 	Verbose bool
 	Quiet   bool
+	Output  io.Writer
+	JSON    bool
 }
 
 // LogLevel represents different logging levels
@@ -21,6 +30,44 @@ const (
 	LevelError   LogLevel = "!"
 )
 
+// logEntry is the JSON-lines rendering of a single log message. URL/Method
+// are populated by Result for structured per-method result events, and left
+// empty (and omitted) for plain Debug/Info/Success/Warning/Error messages.
+type logEntry struct {
+	Timestamp string `json:"ts"`
+	Level     string `json:"level"`
+	Message   string `json:"msg"`
+	URL       string `json:"url,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	Length    int    `json:"length,omitempty"`
+}
+
+// NewLogger builds a Logger from the probe configuration, opening
+// config.LogFile as the sink when set (appending, created if missing) and
+// selecting JSON-lines rendering when config.LogFormat is "json". The
+// returned io.Closer is nil unless a log file was opened, and should be
+// closed by the caller once logging is done.
+func NewLogger(config Config) (*Logger, io.Closer, error) {
+	logger := &Logger{
+		Verbose: config.Verbose,
+		Quiet:   config.Quiet,
+		JSON:    config.LogFormat == "json",
+	}
+
+	if config.LogFile == "" {
+		return logger, nil, nil
+	}
+
+	file, err := os.OpenFile(config.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	logger.Output = file
+
+	return logger, file, nil
+}
+
 // log is the internal method that handles all logging
 func (l *Logger) log(level LogLevel, format string, args ...any) {
 	// Skip logging debug messages if not in verbose mode
@@ -33,11 +80,51 @@ func (l *Logger) log(level LogLevel, format string, args ...any) {
 		return
 	}
 
-	// Format the message with any arguments
-	message := fmt.Sprintf(format, args...)
+	l.write(logEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Level:     string(level),
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Result logs a structured event for a single probed method's outcome -
+// method, target URL, status code, and response length - alongside the
+// table printResults renders, so downstream tooling (jq, a log processor)
+// can consume results from --log-format json without parsing the ANSI table.
+func (l *Logger) Result(targetURL, method string, result Result) {
+	if l.Quiet {
+		return
+	}
+
+	l.write(logEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Level:     string(LevelInfo),
+		Message:   fmt.Sprintf("%s %s -> %d (%d bytes)", method, targetURL, result.StatusCode, result.Length),
+		URL:       targetURL,
+		Method:    method,
+		Status:    result.StatusCode,
+		Length:    result.Length,
+	})
+}
+
+// write renders a single entry to the configured sink, as a JSON line when
+// l.JSON is set or as the "[LEVEL] message" text format otherwise.
+func (l *Logger) write(entry logEntry) {
+	out := l.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if l.JSON {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
 
-	// Print the formatted message with appropriate prefix
-	fmt.Printf("[%s] %s\n", level, message)
+	fmt.Fprintf(out, "[%s] %s\n", entry.Level, entry.Message)
 }
 
 // Debug logs debug information (when verbose is true)
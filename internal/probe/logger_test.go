@@ -0,0 +1,90 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoggerJSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Output: &buf, JSON: true}
+
+	logger.Info("probing %s", "https://example.com")
+
+	var entry logEntry
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Expected valid JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry.Level != string(LevelInfo) {
+		t.Errorf("Expected level %q, got %q", LevelInfo, entry.Level)
+	}
+	if entry.Message != "probing https://example.com" {
+		t.Errorf("Expected message %q, got %q", "probing https://example.com", entry.Message)
+	}
+	if entry.Timestamp == "" {
+		t.Error("Expected a non-empty timestamp")
+	}
+}
+
+func TestLoggerTextOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Output: &buf}
+
+	logger.Success("done")
+
+	if got := buf.String(); got != "[+] done\n" {
+		t.Errorf("Expected %q, got %q", "[+] done\n", got)
+	}
+}
+
+func TestLoggerResultEmitsStructuredEvent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{Output: &buf, JSON: true}
+
+	logger.Result("https://example.com", "GET", Result{StatusCode: 200, Length: 42})
+
+	var entry logEntry
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("Expected valid JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry.URL != "https://example.com" {
+		t.Errorf("Expected url %q, got %q", "https://example.com", entry.URL)
+	}
+	if entry.Method != "GET" {
+		t.Errorf("Expected method %q, got %q", "GET", entry.Method)
+	}
+	if entry.Status != 200 {
+		t.Errorf("Expected status 200, got %d", entry.Status)
+	}
+	if entry.Length != 42 {
+		t.Errorf("Expected length 42, got %d", entry.Length)
+	}
+}
+
+func TestNewLoggerWritesToFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "run.log")
+
+	logger, closer, err := NewLogger(Config{LogFile: logPath, LogFormat: "json"})
+	if err != nil {
+		t.Fatalf("NewLogger() returned error: %v", err)
+	}
+	if closer == nil {
+		t.Fatal("Expected a non-nil closer when LogFile is set")
+	}
+	defer func() { _ = closer.Close() }()
+
+	logger.Error("something broke")
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(content), `"msg":"something broke"`) {
+		t.Errorf("Expected log file to contain the JSON message, got %q", string(content))
+	}
+}
@@ -81,14 +81,14 @@ func TestRunSingleProbe(t *testing.T) {
 	}
 
 	// Run the probe
-	err := runSingleProbe(config, logger)
+	_, err := runSingleProbe(config, logger, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error running single probe: %v", err)
 	}
 
 	// Test with safe mode enabled
 	config.SafeOnly = true
-	err = runSingleProbe(config, logger)
+	_, err = runSingleProbe(config, logger, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error running single probe with safe mode: %v", err)
 	}
@@ -220,7 +220,7 @@ func TestPrintResultsAndExportJSON(t *testing.T) {
 	methods := []string{"DELETE", "GET", "POST", "PUT"}
 
 	// Test printing results
-	printResults(methods, results)
+	printResults(methods, results, Config{})
 
 	// Test exporting to JSON
 	tmpDir := t.TempDir()
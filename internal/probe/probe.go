@@ -2,17 +2,31 @@ package probe
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	mathrand "math/rand"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
+	"net/http/httputil"
 	"net/url"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/proxy"
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/time/rate"
 )
 
 // Config holds the configuration options for the HTTP probe
@@ -32,15 +46,100 @@ type Config struct {
 	InputFile   string
 	CookieJar   string
 	Timeout     int // in seconds
+	Curl        bool
+	Trace       bool
+	ExportCurl  string
+
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	RetryOnStatus  []int
+
+	CORSOrigin string
+
+	ClientCert string
+	ClientKey  string
+	CACert     string
+
+	Recursive bool
+	MaxDepth  int
+
+	BypassMode     bool
+	BypassIP       string
+	BypassPayloads string
+
+	DumpFile string
+
+	LogFile   string
+	LogFormat string
+
+	RateLimit int
+	Backoff   bool
 }
 
 // Result represents the result of an HTTP method test
 type Result struct {
+	StatusCode  int                     `json:"status_code"`
+	Length      int                     `json:"length"`
+	Reason      string                  `json:"reason"`
+	CurlCommand string                  `json:"curl_command,omitempty"`
+	Timing      *Timing                 `json:"timing,omitempty"`
+	Attempts    int                     `json:"attempts,omitempty"`
+	CORS        *CORSResult             `json:"cors,omitempty"`
+	Discovered  []string                `json:"discovered,omitempty"`
+	Bypass      map[string]BypassResult `json:"bypass,omitempty"`
+}
+
+// BypassResult captures the outcome of a single 403/401 bypass mutation
+// attempt for a probed method. Only mutations whose status code or body
+// length differ from that method's baseline result are recorded.
+type BypassResult struct {
 	StatusCode int    `json:"status_code"`
 	Length     int    `json:"length"`
 	Reason     string `json:"reason"`
 }
 
+// CORSResult captures the outcome of a CORS preflight probe for a single
+// method.
+type CORSResult struct {
+	AllowOrigin      string   `json:"allow_origin,omitempty"`
+	AllowMethods     string   `json:"allow_methods,omitempty"`
+	AllowHeaders     string   `json:"allow_headers,omitempty"`
+	AllowCredentials bool     `json:"allow_credentials,omitempty"`
+	MaxAge           string   `json:"max_age,omitempty"`
+	Misconfigured    []string `json:"misconfigured,omitempty"`
+}
+
+// Timing captures per-phase timings for a single HTTP request, collected via
+// an httptrace.ClientTrace when trace mode is enabled.
+type Timing struct {
+	DNSLookup    time.Duration `json:"dns_lookup"`
+	Connect      time.Duration `json:"connect"`
+	TLSHandshake time.Duration `json:"tls_handshake"`
+	TTFB         time.Duration `json:"ttfb"`
+	Total        time.Duration `json:"total"`
+}
+
+// MarshalJSON renders each phase as (possibly fractional) milliseconds rather
+// than raw nanoseconds, so exported results stay readable when piped into jq or other
+// log processors.
+func (t Timing) MarshalJSON() ([]byte, error) {
+	type timingMillis struct {
+		DNSLookupMS    float64 `json:"dns_lookup_ms"`
+		ConnectMS      float64 `json:"connect_ms"`
+		TLSHandshakeMS float64 `json:"tls_handshake_ms"`
+		TTFBMS         float64 `json:"ttfb_ms"`
+		TotalMS        float64 `json:"total_ms"`
+	}
+	return json.Marshal(timingMillis{
+		DNSLookupMS:    t.DNSLookup.Seconds() * 1000,
+		ConnectMS:      t.Connect.Seconds() * 1000,
+		TLSHandshakeMS: t.TLSHandshake.Seconds() * 1000,
+		TTFBMS:         t.TTFB.Seconds() * 1000,
+		TotalMS:        t.Total.Seconds() * 1000,
+	})
+}
+
 // DefaultMethods is the built-in list of HTTP methods to test
 var DefaultMethods = []string{
 	"CHECKIN", "CHECKOUT", "CONNECT", "COPY", "DELETE", "GET", "HEAD", "INDEX",
@@ -61,12 +160,20 @@ var DangerousMethods = map[string]bool{
 
 // Run executes the HTTP methods probe with the given configuration
 func Run(config Config) error {
-	logger := &Logger{
-		Verbose: config.Verbose,
-		Quiet:   config.Quiet,
+	logger, closer, err := NewLogger(config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	if closer != nil {
+		defer func() { _ = closer.Close() }()
 	}
 	logger.Info("Starting HTTP verb enumerating and tampering")
 
+	// Shared across every method and, for InputFile runs, every target URL
+	// in this invocation, so --rate-limit bounds the whole run rather than
+	// resetting per URL.
+	limiter := newRateLimiter(config)
+
 	// If input file specified, process multiple URLs
 	if config.InputFile != "" {
 		urls, err := readLinesFromFile(config.InputFile)
@@ -79,7 +186,7 @@ func Run(config Config) error {
 				logger.Info("Testing URL: %s", targetURL)
 				configCopy := config
 				configCopy.URL = targetURL
-				if err = runSingleProbe(configCopy, logger); err != nil {
+				if err = runProbe(configCopy, logger, limiter); err != nil {
 					logger.Error("Error processing %s: %v", targetURL, err)
 				}
 			}
@@ -88,11 +195,64 @@ func Run(config Config) error {
 	}
 
 	// Run probe on a single URL
-	return runSingleProbe(config, logger)
+	return runProbe(config, logger, limiter)
+}
+
+// runProbe runs the probe against config.URL, following same-origin links
+// discovered in responses up to config.MaxDepth when config.Recursive is set.
+func runProbe(config Config, logger *Logger, limiter *rate.Limiter) error {
+	if !config.Recursive {
+		_, err := runSingleProbe(config, logger, limiter)
+		return err
+	}
+	return crawl(config, logger, limiter)
+}
+
+// crawl performs a breadth-first probe starting at config.URL, harvesting
+// same-origin links from Location/Link/Content-Location headers and, for
+// text/html responses, from the href and src attributes of any element.
+// Discovered URLs are deduplicated via a canonicalized-URL set and enqueued
+// up to config.MaxDepth.
+func crawl(config Config, logger *Logger, limiter *rate.Limiter) error {
+	visited := &sync.Map{}
+	type queueItem struct {
+		url   string
+		depth int
+	}
+	queue := []queueItem{{config.URL, 0}}
+
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		canonical := canonicalizeURL(item.url)
+		if _, seen := visited.LoadOrStore(canonical, true); seen {
+			continue
+		}
+
+		logger.Info("Testing URL: %s (depth %d)", item.url, item.depth)
+		configCopy := config
+		configCopy.URL = item.url
+		discovered, err := runSingleProbe(configCopy, logger, limiter)
+		if err != nil {
+			logger.Error("Error processing %s: %v", item.url, err)
+			continue
+		}
+
+		if item.depth >= config.MaxDepth {
+			continue
+		}
+		for _, next := range discovered {
+			queue = append(queue, queueItem{next, item.depth + 1})
+		}
+	}
+
+	return nil
 }
 
-// runSingleProbe runs the probe on a single URL
-func runSingleProbe(config Config, logger *Logger) error {
+// runSingleProbe runs the probe on a single URL and returns any same-origin
+// URLs discovered in the responses (empty unless config.Recursive is set).
+func runSingleProbe(config Config, logger *Logger, limiter *rate.Limiter) ([]string, error) {
 	// Ensure URL has a protocol
 	if config.URL != "" && !strings.Contains(config.URL, "://") {
 		config.URL = "https://" + config.URL
@@ -102,25 +262,25 @@ func runSingleProbe(config Config, logger *Logger) error {
 	// Build HTTP client
 	client, err := buildHTTPClient(config)
 	if err != nil {
-		return fmt.Errorf("failed to build HTTP client: %w", err)
+		return nil, fmt.Errorf("failed to build HTTP client: %w", err)
 	}
 
 	// Parse headers
 	headers, err := parseHeaders(config.Headers)
 	if err != nil {
-		return fmt.Errorf("failed to parse headers: %w", err)
+		return nil, fmt.Errorf("failed to parse headers: %w", err)
 	}
 
 	// Parse cookies
 	cookies, err := parseCookies(config.Cookies)
 	if err != nil {
-		return fmt.Errorf("failed to parse cookies: %w", err)
+		return nil, fmt.Errorf("failed to parse cookies: %w", err)
 	}
 
 	// Get methods to test
 	methods, err := getMethods(config, logger)
 	if err != nil {
-		return fmt.Errorf("failed to get methods: %w", err)
+		return nil, fmt.Errorf("failed to get methods: %w", err)
 	}
 
 	// Filter out dangerous methods if safe mode is enabled
@@ -139,6 +299,16 @@ func runSingleProbe(config Config, logger *Logger) error {
 		logger.Warning("Use --safe-only to exclude them")
 	}
 
+	// Load the bypass mutation table once up front when bypass mode is on,
+	// rather than re-reading config.BypassPayloads per method tested.
+	var mutations []bypassMutation
+	if config.BypassMode {
+		mutations, err = loadBypassMutations(config.BypassPayloads)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bypass mutations: %w", err)
+		}
+	}
+
 	// Test the methods
 	results := make(map[string]Result)
 	var wg sync.WaitGroup
@@ -150,7 +320,7 @@ func runSingleProbe(config Config, logger *Logger) error {
 			defer wg.Done()
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
-			testMethod(client, config.URL, method, headers, cookies, resultsMutex, results, logger)
+			testMethod(client, config, method, headers, cookies, resultsMutex, results, logger, limiter, mutations)
 		}(method)
 	}
 	wg.Wait()
@@ -164,18 +334,51 @@ func runSingleProbe(config Config, logger *Logger) error {
 
 	// Print results
 	if !config.Quiet {
-		printResults(sortedMethods, results)
+		printResults(sortedMethods, results, config)
 	}
 
 	// Export to JSON if specified
 	if config.JSONFile != "" {
 		if err = exportToJSON(config.JSONFile, results); err != nil {
-			return fmt.Errorf("failed to export results to JSON: %w", err)
+			return nil, fmt.Errorf("failed to export results to JSON: %w", err)
 		}
 		logger.Success("Results exported to %s", config.JSONFile)
 	}
 
-	return nil
+	// Export curl commands if specified
+	if config.ExportCurl != "" {
+		if err = exportCurlCommands(config.ExportCurl, sortedMethods, results); err != nil {
+			return nil, fmt.Errorf("failed to export curl commands: %w", err)
+		}
+		logger.Success("Curl commands exported to %s", config.ExportCurl)
+	}
+
+	// Persist the cookie jar built up over the run (including any cookies
+	// set in responses) if requested
+	if config.CookieJar != "" {
+		if err = writeCookieJar(client.Jar, config.URL, config.CookieJar); err != nil {
+			return nil, fmt.Errorf("failed to write cookie jar: %w", err)
+		}
+		logger.Success("Cookies written to %s", config.CookieJar)
+	}
+
+	var discovered []string
+	if config.Recursive {
+		discovered = aggregateDiscovered(sortedMethods, results)
+	}
+
+	return discovered, nil
+}
+
+// newRateLimiter builds a token-bucket limiter enforcing config.RateLimit
+// requests per second, shared across every method tested (and, for
+// InputFile runs, every target URL) in one invocation. Returns nil when
+// RateLimit is unset, meaning no throttling.
+func newRateLimiter(config Config) *rate.Limiter {
+	if config.RateLimit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(config.RateLimit), config.RateLimit)
 }
 
 // buildHTTPClient creates an HTTP client based on the configuration
@@ -195,13 +398,47 @@ func buildHTTPClient(config Config) (*http.Client, error) {
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: config.Insecure},
 	}
 
+	// Configure mutual TLS client authentication if specified
+	if config.ClientCert != "" && config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate/key pair: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// Configure a custom CA to validate the server certificate against, if specified
+	if config.CACert != "" {
+		caCert, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA certificate: %s", config.CACert)
+		}
+		transport.TLSClientConfig.RootCAs = caPool
+	}
+
 	// Configure proxy if specified
 	if config.Proxy != "" {
 		proxyURL, err := url.Parse(config.Proxy)
 		if err != nil {
 			return nil, fmt.Errorf("invalid proxy URL: %w", err)
 		}
-		transport.Proxy = http.ProxyURL(proxyURL)
+
+		switch proxyURL.Scheme {
+		case "socks5", "socks5h":
+			dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SOCKS5 proxy URL: %w", err)
+			}
+			transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.Dial(network, addr)
+			}
+		default:
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
 	}
 	client.Transport = transport
 
@@ -212,6 +449,17 @@ func buildHTTPClient(config Config) (*http.Client, error) {
 		}
 	}
 
+	// Attach a persistent cookie jar so Set-Cookie responses carry over
+	// between requests (and retries), and pre-seed it from config.Cookies.
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	if err = seedCookieJar(jar, config.Cookies, config.URL); err != nil {
+		return nil, err
+	}
+	client.Jar = jar
+
 	return client, nil
 }
 
@@ -303,6 +551,103 @@ func addCookie(cookies map[string]string, cookiePair string) {
 	cookies[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 }
 
+// seedCookieJar pre-populates jar from cookieStr before the first request is
+// sent: a Netscape/curl cookies.txt file (preserving domain/path/expiry per
+// cookie) if cookieStr names an existing file, otherwise a raw
+// "name=value; name2=value2" Cookie string applied to targetURL's origin.
+func seedCookieJar(jar http.CookieJar, cookieStr, targetURL string) error {
+	if cookieStr == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(cookieStr); err == nil {
+		return seedCookieJarFromNetscapeFile(jar, cookieStr)
+	}
+
+	target, err := url.Parse(targetURL)
+	if err != nil || target.Host == "" {
+		// No usable origin to seed yet (e.g. building a client before the
+		// URL is finalized); callers without a concrete target can skip.
+		return nil
+	}
+
+	var httpCookies []*http.Cookie
+	for _, part := range strings.Split(cookieStr, ";") {
+		name, value, ok := splitCookiePair(strings.TrimSpace(part))
+		if !ok {
+			continue
+		}
+		httpCookies = append(httpCookies, &http.Cookie{Name: name, Value: value})
+	}
+	jar.SetCookies(target, httpCookies)
+	return nil
+}
+
+// seedCookieJarFromNetscapeFile reads a Netscape-format cookies.txt file and
+// installs each entry into jar under its own domain and path, preserving
+// the domain/path/expiry metadata that parseCookies discards.
+func seedCookieJarFromNetscapeFile(jar http.CookieJar, path string) error {
+	lines, err := readLinesFromFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read cookie jar file: %w", err)
+	}
+
+	byDomain := make(map[string][]*http.Cookie)
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			continue
+		}
+		domain := strings.TrimPrefix(fields[0], ".")
+		expiresUnix, _ := strconv.ParseInt(fields[4], 10, 64)
+
+		cookie := &http.Cookie{
+			Name:   fields[5],
+			Value:  fields[6],
+			Path:   fields[2],
+			Secure: strings.EqualFold(fields[3], "TRUE"),
+		}
+		if expiresUnix > 0 {
+			cookie.Expires = time.Unix(expiresUnix, 0)
+		}
+		byDomain[domain] = append(byDomain[domain], cookie)
+	}
+
+	for domain, cookies := range byDomain {
+		jar.SetCookies(&url.URL{Scheme: "https", Host: domain}, cookies)
+	}
+	return nil
+}
+
+// splitCookiePair splits a single "name=value" cookie pair.
+func splitCookiePair(pair string) (name, value string, ok bool) {
+	parts := strings.SplitN(pair, "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
+
+// writeCookieJar writes jar's cookies for targetURL's origin to filename in
+// Netscape cookies.txt format. The stdlib cookiejar.Jar only exposes
+// Name/Value through Cookies, so exported entries use targetURL's host and
+// a root path rather than the cookie's original domain/path/expiry.
+func writeCookieJar(jar http.CookieJar, targetURL, filename string) error {
+	target, err := url.Parse(targetURL)
+	if err != nil {
+		return fmt.Errorf("invalid target URL for cookie jar export: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Netscape HTTP Cookie File\n")
+	for _, cookie := range jar.Cookies(target) {
+		b.WriteString(fmt.Sprintf("%s\tTRUE\t/\tFALSE\t0\t%s\t%s\n",
+			target.Hostname(), cookie.Name, cookie.Value))
+	}
+
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
+
 // getMethods retrieves the list of HTTP methods to test
 func getMethods(config Config, logger *Logger) ([]string, error) {
 	var methods []string
@@ -392,40 +737,99 @@ func getMethodsFromOptions(config Config, logger *Logger) ([]string, error) {
 	return methods, err
 }
 
-// testMethod tests a single HTTP method against the target URL
+// testMethod tests a single HTTP method against the target URL, retrying on
+// transient network errors or configured status codes before recording the
+// final result.
 func testMethod(
-	client *http.Client, targetURL, method string, headers http.Header,
+	client *http.Client, config Config, method string, headers http.Header,
 	cookies map[string]string, mutex *sync.Mutex, results map[string]Result, logger *Logger,
+	limiter *rate.Limiter, mutations []bypassMutation,
 ) {
-	req, err := http.NewRequest(method, targetURL, nil)
-	if err != nil {
-		logger.Debug("Failed to create request for method %s: %v", method, err)
-		return
+	targetURL := config.URL
+
+	var curlCommand string
+	if config.Curl || config.ExportCurl != "" {
+		curlCommand = buildCurlCommand(method, targetURL, headers, cookies, config)
 	}
 
-	// Add headers
-	for key, values := range headers {
-		for _, value := range values {
-			req.Header.Add(key, value)
+	var (
+		resp     *http.Response
+		reqErr   error
+		timing   *Timing
+		attempt  int
+		lastReq  *http.Request
+		sentAt   time.Time
+		duration time.Duration
+	)
+
+	for {
+		attempt++
+
+		req, err := newMethodRequest(method, targetURL, headers)
+		if err != nil {
+			logger.Debug("Failed to create request for method %s: %v", method, err)
+			return
+		}
+
+		if limiter != nil {
+			_ = limiter.Wait(req.Context())
+		}
+
+		var requestStart time.Time
+		if config.Trace {
+			timing = &Timing{}
+			req, requestStart = withClientTrace(req, timing)
+		}
+
+		logger.Debug("Testing method: %s (attempt %d)", method, attempt)
+		lastReq = req
+		sentAt = time.Now()
+		resp, reqErr = client.Do(req)
+		duration = time.Since(sentAt)
+
+		if timing != nil && reqErr == nil {
+			timing.Total = time.Since(requestStart)
+		}
+
+		retryableStatus := reqErr == nil && isRetryableStatus(resp.StatusCode, config.RetryOnStatus)
+		if config.Backoff && limiter != nil && retryableStatus {
+			backedOff := rate.Limit(float64(limiter.Limit()) / 2)
+			if backedOff < 1 {
+				backedOff = 1
+			}
+			limiter.SetLimit(backedOff)
+			logger.Debug("Rate limit backed off to %.2f req/s after %d on method %s", float64(backedOff), resp.StatusCode, method)
+		}
+		if (reqErr == nil && !retryableStatus) || attempt > config.MaxRetries {
+			break
+		}
+
+		delay := retryBackoff(attempt-1, config.RetryBaseDelay, config.RetryMaxDelay)
+		if reqErr == nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			_ = resp.Body.Close()
 		}
-	}
 
-	// Add cookies
-	for name, value := range cookies {
-		req.AddCookie(&http.Cookie{Name: name, Value: value})
+		logger.Debug("Retrying method %s after %s (attempt %d/%d)", method, delay, attempt, config.MaxRetries)
+		time.Sleep(delay)
 	}
 
-	logger.Debug("Testing method: %s", method)
-	resp, err := client.Do(req)
-	if err != nil {
-		logger.Debug("Request failed for method %s: %v", method, err)
-		mutex.Lock()
-		results[method] = Result{
-			StatusCode: 0,
-			Length:     0,
-			Reason:     err.Error(),
+	if reqErr != nil {
+		logger.Debug("Request failed for method %s: %v", method, reqErr)
+		errResult := Result{
+			StatusCode:  0,
+			Length:      0,
+			Reason:      reqErr.Error(),
+			CurlCommand: curlCommand,
+			Timing:      timing,
+			Attempts:    attempt,
 		}
+		mutex.Lock()
+		results[method] = errResult
 		mutex.Unlock()
+		logger.Result(targetURL, method, errResult)
 		return
 	}
 	defer func(body io.ReadCloser) {
@@ -438,17 +842,292 @@ func testMethod(
 		logger.Debug("Failed to read response body for method %s: %v", method, err)
 	}
 
-	mutex.Lock()
-	results[method] = Result{
-		StatusCode: resp.StatusCode,
-		Length:     len(body),
-		Reason:     resp.Status,
+	if config.DumpFile != "" {
+		if err = dumpTransaction(config.DumpFile, method, lastReq, resp, body, sentAt, duration); err != nil {
+			logger.Debug("Failed to dump transaction for method %s: %v", method, err)
+		}
+	}
+
+	if attempt > 1 {
+		logger.Debug("Method %s succeeded after %d attempts", method, attempt)
+	}
+
+	result := Result{
+		StatusCode:  resp.StatusCode,
+		Length:      len(body),
+		Reason:      resp.Status,
+		CurlCommand: curlCommand,
+		Timing:      timing,
+		Attempts:    attempt,
 	}
+
+	if config.CORSOrigin != "" {
+		result.CORS = probeCORSPreflight(client, config, method, headers, result.StatusCode)
+	}
+
+	if config.Recursive {
+		result.Discovered = discoverLinks(resp, body, targetURL)
+	}
+
+	if config.BypassMode {
+		result.Bypass = probeBypasses(client, config, method, headers, result, mutations)
+	}
+
+	mutex.Lock()
+	results[method] = result
 	mutex.Unlock()
+	logger.Result(targetURL, method, result)
+}
+
+// probeCORSPreflight sends a CORS preflight OPTIONS request for method,
+// carrying the Origin/Access-Control-Request-* headers a browser would send,
+// and parses the server's response into a CORSResult.
+func probeCORSPreflight(client *http.Client, config Config, method string, headers http.Header, actualStatus int) *CORSResult {
+	req, err := http.NewRequest(http.MethodOptions, config.URL, nil)
+	if err != nil {
+		return nil
+	}
+
+	req.Header.Set("Origin", config.CORSOrigin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if names := headerNames(headers); len(names) > 0 {
+		req.Header.Set("Access-Control-Request-Headers", strings.Join(names, ", "))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(resp.Body)
+
+	result := &CORSResult{
+		AllowOrigin:      resp.Header.Get("Access-Control-Allow-Origin"),
+		AllowMethods:     resp.Header.Get("Access-Control-Allow-Methods"),
+		AllowHeaders:     resp.Header.Get("Access-Control-Allow-Headers"),
+		AllowCredentials: strings.EqualFold(resp.Header.Get("Access-Control-Allow-Credentials"), "true"),
+		MaxAge:           resp.Header.Get("Access-Control-Max-Age"),
+	}
+	result.Misconfigured = detectCORSMisconfigurations(result, config.CORSOrigin, method, actualStatus)
+
+	return result
+}
+
+// headerNames returns the sorted list of header names present in headers.
+func headerNames(headers http.Header) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// detectCORSMisconfigurations flags common CORS setup mistakes observed in a
+// preflight response: a wildcard origin combined with credentials, an
+// arbitrary origin reflected verbatim instead of validated against an
+// allowlist, and methods CORS advertises as allowed that the real request
+// was actually rejected for.
+func detectCORSMisconfigurations(result *CORSResult, requestOrigin, method string, actualStatus int) []string {
+	var issues []string
+
+	if result.AllowOrigin == "*" && result.AllowCredentials {
+		issues = append(issues, "wildcard origin allowed alongside Access-Control-Allow-Credentials")
+	}
+	if result.AllowOrigin != "" && result.AllowOrigin == requestOrigin && requestOrigin != "*" {
+		issues = append(issues, "arbitrary origin reflected verbatim in Access-Control-Allow-Origin")
+	}
+	if result.AllowMethods != "" && allowsMethod(result.AllowMethods, method) && actualStatus >= 400 {
+		issues = append(issues, fmt.Sprintf("CORS advertises %s as allowed, but the actual request got status %d", method, actualStatus))
+	}
+
+	return issues
+}
+
+// allowsMethod reports whether method appears as its own token in a
+// comma-joined Access-Control-Allow-Methods value, rather than merely as a
+// substring of some other token.
+func allowsMethod(allowMethods, method string) bool {
+	for _, allowed := range strings.Split(allowMethods, ",") {
+		if strings.EqualFold(strings.TrimSpace(allowed), method) {
+			return true
+		}
+	}
+	return false
+}
+
+// newMethodRequest builds a fresh request for method/targetURL with headers
+// applied, so each retry attempt gets its own *http.Request. Cookies are not
+// attached here: buildHTTPClient always seeds client.Jar from config.Cookies,
+// and http.Client.Do pulls cookies back out of the jar for us, so adding them
+// here too would send every cookie twice on the wire.
+func newMethodRequest(method, targetURL string, headers http.Header) (*http.Request, error) {
+	req, err := http.NewRequest(method, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return req, nil
+}
+
+// defaultRetryOnStatus is used when Config.RetryOnStatus is empty.
+var defaultRetryOnStatus = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// isRetryableStatus reports whether statusCode should trigger a retry.
+func isRetryableStatus(statusCode int, retryOnStatus []int) bool {
+	codes := retryOnStatus
+	if len(codes) == 0 {
+		codes = defaultRetryOnStatus
+	}
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// retryBackoff computes the exponential backoff delay for the given 0-indexed
+// attempt, capped at maxDelay, plus uniform jitter in [0, delay/2).
+func retryBackoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+
+	delay := base * time.Duration(1<<uint(attempt))
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+
+	var jitter time.Duration
+	if half := int64(delay / 2); half > 0 {
+		jitter = time.Duration(mathrand.Int63n(half))
+	}
+	return delay + jitter
+}
+
+// parseRetryAfter parses a Retry-After header value, supporting both the
+// delta-seconds and HTTP-date forms.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			seconds = 0
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// withClientTrace attaches an httptrace.ClientTrace to req that records DNS
+// lookup, TCP connect, TLS handshake, and time-to-first-byte durations into
+// timing. It returns the instrumented request and the time right before the
+// request is sent, so the caller can compute the total duration once
+// client.Do has returned the response.
+func withClientTrace(req *http.Request, timing *Timing) (*http.Request, time.Time) {
+	var dnsStart, connectStart, tlsStart time.Time
+	requestStart := time.Now()
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			timing.DNSLookup = time.Since(dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			timing.Connect = time.Since(connectStart)
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			timing.TLSHandshake = time.Since(tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			timing.TTFB = time.Since(requestStart)
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), requestStart
+}
+
+// shellEscape wraps s in single quotes for safe use as a single POSIX shell
+// argument, escaping any embedded single quotes.
+func shellEscape(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// buildCurlCommand renders a curl command line equivalent to the request
+// that testMethod is about to make, so a user can reproduce a specific
+// result outside the tool.
+func buildCurlCommand(method, targetURL string, headers http.Header, cookies map[string]string, config Config) string {
+	parts := []string{"curl", "-s", "-X", shellEscape(method)}
+
+	headerKeys := make([]string, 0, len(headers))
+	for key := range headers {
+		headerKeys = append(headerKeys, key)
+	}
+	sort.Strings(headerKeys)
+	for _, key := range headerKeys {
+		for _, value := range headers[key] {
+			parts = append(parts, "-H", shellEscape(key+": "+value))
+		}
+	}
+
+	if len(cookies) > 0 {
+		names := make([]string, 0, len(cookies))
+		for name := range cookies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		pairs := make([]string, 0, len(names))
+		for _, name := range names {
+			pairs = append(pairs, name+"="+cookies[name])
+		}
+		parts = append(parts, "--cookie", shellEscape(strings.Join(pairs, "; ")))
+	}
+
+	if config.Insecure {
+		parts = append(parts, "-k")
+	}
+	if config.FollowRedir {
+		parts = append(parts, "-L")
+	}
+	if config.Proxy != "" {
+		parts = append(parts, "-x", shellEscape(config.Proxy))
+	}
+
+	parts = append(parts, shellEscape(targetURL))
+
+	return strings.Join(parts, " ")
 }
 
 // printResults prints the test results in a table format
-func printResults(methods []string, results map[string]Result) {
+func printResults(methods []string, results map[string]Result, config Config) {
 	// Print header
 	fmt.Printf("\n%-15s %-10s %-10s %s\n", "METHOD", "STATUS", "LENGTH", "REASON")
 	fmt.Printf("%-15s %-10s %-10s %s\n", "------", "------", "------", "------")
@@ -487,6 +1166,30 @@ func printResults(methods []string, results map[string]Result) {
 			result.Length,
 			reasonColor, result.Reason, resetColor,
 		)
+
+		if config.Curl && result.CurlCommand != "" {
+			fmt.Printf("    %s\n", result.CurlCommand)
+		}
+
+		if config.Trace && result.Timing != nil {
+			t := result.Timing
+			fmt.Printf("    dns=%s connect=%s tls=%s ttfb=%s total=%s\n",
+				t.DNSLookup, t.Connect, t.TLSHandshake, t.TTFB, t.Total)
+		}
+
+		if config.CORSOrigin != "" && result.CORS != nil {
+			fmt.Printf("    cors: allow-origin=%q allow-methods=%q allow-credentials=%v\n",
+				result.CORS.AllowOrigin, result.CORS.AllowMethods, result.CORS.AllowCredentials)
+			for _, issue := range result.CORS.Misconfigured {
+				fmt.Printf("    \033[31m! %s\033[0m\n", issue)
+			}
+		}
+
+		if config.BypassMode && len(result.Bypass) > 0 {
+			for id, bypass := range result.Bypass {
+				fmt.Printf("    \033[33m! bypass %s -> %d (%d bytes)\033[0m\n", id, bypass.StatusCode, bypass.Length)
+			}
+		}
 	}
 	fmt.Println()
 }
@@ -501,6 +1204,66 @@ func exportToJSON(filename string, results map[string]Result) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
+// exportCurlCommands writes one curl invocation per probed method to
+// filename, in method order, so users can replay the exact requests that
+// were sent during the probe.
+func exportCurlCommands(filename string, methods []string, results map[string]Result) error {
+	var b strings.Builder
+	for _, method := range methods {
+		result, ok := results[method]
+		if !ok || result.CurlCommand == "" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("# %s\n%s\n", method, result.CurlCommand))
+	}
+
+	return os.WriteFile(filename, []byte(b.String()), 0644)
+}
+
+// dumpMutex serializes appends to Config.DumpFile across the concurrent
+// method-testing workers.
+var dumpMutex sync.Mutex
+
+// dumpTransaction appends the raw wire request and response for a single
+// probed method to filename, preceded by a header block identifying the
+// method, target URL, timestamp, and duration, and separated from other
+// entries by a delimiter.
+func dumpTransaction(filename string, method string, req *http.Request, resp *http.Response, body []byte, sentAt time.Time, duration time.Duration) error {
+	reqDump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		return fmt.Errorf("failed to dump request: %w", err)
+	}
+
+	respCopy := *resp
+	respCopy.Body = io.NopCloser(bytes.NewReader(body))
+	respDump, err := httputil.DumpResponse(&respCopy, true)
+	if err != nil {
+		return fmt.Errorf("failed to dump response: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat("=", 80) + "\n")
+	b.WriteString(fmt.Sprintf("Method: %s\nURL: %s\nTime: %s\nDuration: %s\n",
+		method, req.URL, sentAt.Format(time.RFC3339), duration))
+	b.WriteString(strings.Repeat("-", 80) + "\n")
+	b.Write(reqDump)
+	b.WriteString("\n" + strings.Repeat("-", 80) + "\n")
+	b.Write(respDump)
+	b.WriteString("\n")
+
+	dumpMutex.Lock()
+	defer dumpMutex.Unlock()
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open dump file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = f.WriteString(b.String())
+	return err
+}
+
 // readLinesFromFile reads lines from a file
 func readLinesFromFile(filename string) ([]string, error) {
 	file, err := os.Open(filename)
@@ -526,3 +1289,112 @@ func readLinesFromFile(filename string) ([]string, error) {
 
 	return lines, nil
 }
+
+// aggregateDiscovered collects the deduplicated union of all Discovered
+// URLs across every tested method's result, in method order.
+func aggregateDiscovered(methods []string, results map[string]Result) []string {
+	seen := make(map[string]bool)
+	var discovered []string
+	for _, method := range methods {
+		for _, link := range results[method].Discovered {
+			if !seen[link] {
+				seen[link] = true
+				discovered = append(discovered, link)
+			}
+		}
+	}
+	return discovered
+}
+
+// canonicalizeURL normalizes a URL for deduplication purposes: it lowercases
+// the scheme and host and drops any fragment. URLs that fail to parse are
+// returned unchanged.
+func canonicalizeURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// discoverLinks harvests same-origin URLs reachable from a probe response:
+// the Location, Link, and Content-Location headers, and, for text/html
+// bodies, href/src attributes walked out of the parsed document. URLs that
+// don't resolve or aren't on the same host as baseURL are discarded.
+func discoverLinks(resp *http.Response, body []byte, baseURL string) []string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	candidates = append(candidates, resp.Header.Get("Location"), resp.Header.Get("Content-Location"))
+	candidates = append(candidates, parseLinkHeader(resp.Header.Get("Link"))...)
+
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		candidates = append(candidates, htmlLinks(body)...)
+	}
+
+	seen := make(map[string]bool)
+	var discovered []string
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		resolved, err := base.Parse(candidate)
+		if err != nil || resolved.Host != base.Host {
+			continue
+		}
+		link := resolved.String()
+		if !seen[link] {
+			seen[link] = true
+			discovered = append(discovered, link)
+		}
+	}
+	return discovered
+}
+
+// parseLinkHeader extracts the URL portion of each entry in an RFC 8288
+// Link header (e.g. `<https://example.com/next>; rel="next"`).
+func parseLinkHeader(header string) []string {
+	var links []string
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		start := strings.Index(part, "<")
+		end := strings.Index(part, ">")
+		if start == -1 || end == -1 || end <= start {
+			continue
+		}
+		links = append(links, part[start+1:end])
+	}
+	return links
+}
+
+// htmlLinks walks an HTML document and returns the href/src attribute
+// values of every element, for use as recursive-scan candidates.
+func htmlLinks(body []byte) []string {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var links []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			for _, attr := range n.Attr {
+				if attr.Key == "href" || attr.Key == "src" {
+					links = append(links, attr.Val)
+				}
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	return links
+}
@@ -1,16 +1,74 @@
 package probe
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
+// generateTestCertKeyPair writes a self-signed certificate/key pair to
+// tmpDir and returns their paths, for tests that exercise mTLS wiring.
+func generateTestCertKeyPair(t *testing.T, tmpDir string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gohttpprobe-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create test certificate: %v", err)
+	}
+
+	certPath = filepath.Join(tmpDir, "test-cert.pem")
+	keyPath = filepath.Join(tmpDir, "test-key.pem")
+
+	certOut, err := os.Create(certPath)
+	if err != nil {
+		t.Fatalf("Failed to create cert file: %v", err)
+	}
+	defer func() { _ = certOut.Close() }()
+	if err = pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: certDER}); err != nil {
+		t.Fatalf("Failed to write cert PEM: %v", err)
+	}
+
+	keyOut, err := os.Create(keyPath)
+	if err != nil {
+		t.Fatalf("Failed to create key file: %v", err)
+	}
+	defer func() { _ = keyOut.Close() }()
+	if err = pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}); err != nil {
+		t.Fatalf("Failed to write key PEM: %v", err)
+	}
+
+	return certPath, keyPath
+}
+
 func TestParseHeaders(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -219,6 +277,75 @@ func TestParseCookiesFromFile(t *testing.T) {
 	}
 }
 
+func TestSeedCookieJarFromRawString(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("Failed to create cookie jar: %v", err)
+	}
+
+	if err = seedCookieJar(jar, "session=abc123; theme=dark", "https://example.com"); err != nil {
+		t.Fatalf("seedCookieJar() returned error: %v", err)
+	}
+
+	target, _ := url.Parse("https://example.com")
+	got := make(map[string]string)
+	for _, c := range jar.Cookies(target) {
+		got[c.Name] = c.Value
+	}
+
+	if got["session"] != "abc123" || got["theme"] != "dark" {
+		t.Errorf("Expected jar to contain session=abc123 and theme=dark, got %v", got)
+	}
+}
+
+func TestSeedCookieJarFromNetscapeFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	cookieFile := filepath.Join(tmpDir, "cookies.txt")
+	content := "# Netscape HTTP Cookie File\nexample.com\tTRUE\t/\tFALSE\t0\tsession\tabc123\n"
+	if err := os.WriteFile(cookieFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test cookie file: %v", err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("Failed to create cookie jar: %v", err)
+	}
+
+	if err = seedCookieJar(jar, cookieFile, "https://example.com"); err != nil {
+		t.Fatalf("seedCookieJar() returned error: %v", err)
+	}
+
+	target, _ := url.Parse("https://example.com")
+	cookies := jar.Cookies(target)
+	if len(cookies) != 1 || cookies[0].Name != "session" || cookies[0].Value != "abc123" {
+		t.Errorf("Expected jar to contain session=abc123, got %v", cookies)
+	}
+}
+
+func TestWriteCookieJar(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("Failed to create cookie jar: %v", err)
+	}
+
+	target, _ := url.Parse("https://example.com")
+	jar.SetCookies(target, []*http.Cookie{{Name: "session", Value: "abc123"}})
+
+	tmpDir := t.TempDir()
+	outFile := filepath.Join(tmpDir, "out-cookies.txt")
+	if err = writeCookieJar(jar, "https://example.com", outFile); err != nil {
+		t.Fatalf("writeCookieJar() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("Failed to read written cookie jar file: %v", err)
+	}
+	if !strings.Contains(string(content), "session\tabc123") {
+		t.Errorf("Expected exported cookie jar to contain session\\tabc123, got %q", string(content))
+	}
+}
+
 func TestGetMethods(t *testing.T) {
 	// Create a temporary test file
 	tmpDir := t.TempDir()
@@ -310,6 +437,22 @@ func TestBuildHTTPClient(t *testing.T) {
 			expectError:   true,
 			checkRedirect: false,
 		},
+		{
+			name: "Valid SOCKS5 Proxy",
+			config: Config{
+				Proxy: "socks5://127.0.0.1:1080",
+			},
+			expectError:   false,
+			checkRedirect: false,
+		},
+		{
+			name: "Invalid SOCKS5 Proxy",
+			config: Config{
+				Proxy: "socks5://invalid host\x7f", // control character, fails url.Parse
+			},
+			expectError:   true,
+			checkRedirect: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -346,10 +489,53 @@ func TestBuildHTTPClient(t *testing.T) {
 					t.Errorf("Expected CheckRedirect to be set, but it's nil")
 				}
 			}
+
+			// Check that a SOCKS5 proxy installs a dialer instead of transport.Proxy
+			if strings.HasPrefix(tc.config.Proxy, "socks5") {
+				if transport.DialContext == nil {
+					t.Errorf("Expected DialContext to be set for SOCKS5 proxy, but it's nil")
+				}
+				if transport.Proxy != nil {
+					t.Errorf("Expected Proxy to be unset for SOCKS5 proxy")
+				}
+			}
 		})
 	}
 }
 
+func TestBuildHTTPClientMTLS(t *testing.T) {
+	tmpDir := t.TempDir()
+	certPath, keyPath := generateTestCertKeyPair(t, tmpDir)
+
+	client, err := buildHTTPClient(Config{
+		ClientCert: certPath,
+		ClientKey:  keyPath,
+		CACert:     certPath, // self-signed, so the cert also works as its own CA
+	})
+	if err != nil {
+		t.Fatalf("buildHTTPClient() returned error: %v", err)
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.Transport)
+	}
+	if len(transport.TLSClientConfig.Certificates) != 1 {
+		t.Errorf("Expected 1 client certificate, got %d", len(transport.TLSClientConfig.Certificates))
+	}
+	if transport.TLSClientConfig.RootCAs == nil {
+		t.Errorf("Expected RootCAs to be populated")
+	}
+
+	if _, err = buildHTTPClient(Config{ClientCert: "missing.pem", ClientKey: "missing.key"}); err == nil {
+		t.Errorf("Expected error when client cert/key files don't exist")
+	}
+
+	if _, err = buildHTTPClient(Config{CACert: "missing-ca.pem"}); err == nil {
+		t.Errorf("Expected error when CA cert file doesn't exist")
+	}
+}
+
 func TestExportToJSON(t *testing.T) {
 	// Setup test data
 	results := map[string]Result{
@@ -407,6 +593,38 @@ func TestExportToJSON(t *testing.T) {
 	}
 }
 
+func TestExportCurlCommands(t *testing.T) {
+	results := map[string]Result{
+		"GET":  {CurlCommand: "curl -X GET 'http://example.com'"},
+		"POST": {CurlCommand: "curl -X POST 'http://example.com'"},
+		"HEAD": {}, // no curl command recorded, should be skipped
+	}
+	methods := []string{"GET", "HEAD", "POST"}
+
+	tmpDir := t.TempDir()
+	curlFile := filepath.Join(tmpDir, "commands.sh")
+
+	if err := exportCurlCommands(curlFile, methods, results); err != nil {
+		t.Fatalf("exportCurlCommands() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(curlFile)
+	if err != nil {
+		t.Fatalf("Failed to read curl export file: %v", err)
+	}
+
+	got := string(content)
+	if !strings.Contains(got, results["GET"].CurlCommand) {
+		t.Errorf("Expected output to contain GET curl command, got %q", got)
+	}
+	if !strings.Contains(got, results["POST"].CurlCommand) {
+		t.Errorf("Expected output to contain POST curl command, got %q", got)
+	}
+	if strings.Contains(got, "# HEAD") {
+		t.Errorf("Expected HEAD to be skipped since it has no curl command, got %q", got)
+	}
+}
+
 func TestReadLinesFromFile(t *testing.T) {
 	// Create a temporary test file
 	tmpDir := t.TempDir()
@@ -440,6 +658,597 @@ func TestReadLinesFromFile(t *testing.T) {
 	}
 }
 
+func TestBuildCurlCommand(t *testing.T) {
+	config := Config{
+		Insecure:    true,
+		FollowRedir: true,
+		Proxy:       "http://localhost:8080",
+	}
+	headers := http.Header{
+		"User-Agent": []string{"it's a test"},
+	}
+	cookies := map[string]string{
+		"session": "abc123",
+	}
+
+	cmd := buildCurlCommand("GET", "https://example.com/path", headers, cookies, config)
+
+	expectedParts := []string{
+		"curl -s -X 'GET'",
+		`-H 'User-Agent: it'\''s a test'`,
+		"--cookie 'session=abc123'",
+		"-k",
+		"-L",
+		"-x 'http://localhost:8080'",
+		"'https://example.com/path'",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(cmd, part) {
+			t.Errorf("Expected curl command to contain %q, got %q", part, cmd)
+		}
+	}
+}
+
+func TestShellEscape(t *testing.T) {
+	testCases := map[string]string{
+		"simple":      "'simple'",
+		"has space":   "'has space'",
+		"it's quoted": `'it'\''s quoted'`,
+		"$(rm -rf /)": `'$(rm -rf /)'`,
+	}
+
+	for input, expected := range testCases {
+		if got := shellEscape(input); got != expected {
+			t.Errorf("shellEscape(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestProbeCORSPreflight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE")
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL, CORSOrigin: "https://evil.example"}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	result := probeCORSPreflight(client, config, "DELETE", nil, http.StatusForbidden)
+	if result == nil {
+		t.Fatalf("Expected a CORSResult, got nil")
+	}
+	if result.AllowOrigin != config.CORSOrigin {
+		t.Errorf("Expected AllowOrigin %q, got %q", config.CORSOrigin, result.AllowOrigin)
+	}
+	if !result.AllowCredentials {
+		t.Errorf("Expected AllowCredentials to be true")
+	}
+
+	foundReflected := false
+	foundRejected := false
+	for _, issue := range result.Misconfigured {
+		if strings.Contains(issue, "reflected verbatim") {
+			foundReflected = true
+		}
+		if strings.Contains(issue, "actual request got status") {
+			foundRejected = true
+		}
+	}
+	if !foundReflected {
+		t.Errorf("Expected a reflected-origin misconfiguration, got %v", result.Misconfigured)
+	}
+	if !foundRejected {
+		t.Errorf("Expected a rejected-despite-CORS misconfiguration, got %v", result.Misconfigured)
+	}
+}
+
+func TestDetectCORSMisconfigurationsMatchesWholeMethodToken(t *testing.T) {
+	result := &CORSResult{AllowMethods: "GET, POST"}
+
+	if issues := detectCORSMisconfigurations(result, "", "GE", http.StatusForbidden); len(issues) != 0 {
+		t.Errorf("Expected no issues for method %q that is only a substring of an allowed token, got %v", "GE", issues)
+	}
+
+	if issues := detectCORSMisconfigurations(result, "", "GET", http.StatusForbidden); len(issues) == 0 {
+		t.Error("Expected an issue for GET, which is allowed but was rejected")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "gohttpprobe.yaml")
+	content := `
+profiles:
+  staging:
+    url: https://staging.example.com
+    threads: 8
+    safe_only: true
+    headers:
+      - "X-Env: staging"
+  prod:
+    url: https://example.com
+    threads: 3
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	config, err := LoadConfig(configPath, "staging")
+	if err != nil {
+		t.Fatalf("LoadConfig() returned error: %v", err)
+	}
+	if config.URL != "https://staging.example.com" {
+		t.Errorf("Expected URL %q, got %q", "https://staging.example.com", config.URL)
+	}
+	if config.Threads != 8 {
+		t.Errorf("Expected Threads 8, got %d", config.Threads)
+	}
+	if !config.SafeOnly {
+		t.Errorf("Expected SafeOnly to be true")
+	}
+
+	if _, err = LoadConfig(configPath, "missing"); err == nil {
+		t.Errorf("Expected error for missing profile, got nil")
+	}
+
+	if _, err = LoadConfig(configPath, ""); err == nil {
+		t.Errorf("Expected error when profile is ambiguous, got nil")
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		statusCode    int
+		retryOnStatus []int
+		expected      bool
+	}{
+		{"Default 503", 503, nil, true},
+		{"Default 200", 200, nil, false},
+		{"Custom list match", 418, []int{418}, true},
+		{"Custom list no match", 503, []int{418}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableStatus(tc.statusCode, tc.retryOnStatus); got != tc.expected {
+				t.Errorf("isRetryableStatus(%d, %v) = %v, want %v", tc.statusCode, tc.retryOnStatus, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("2")
+	if !ok || delay != 2*time.Second {
+		t.Errorf("parseRetryAfter(\"2\") = %v, %v, want 2s, true", delay, ok)
+	}
+
+	if _, ok = parseRetryAfter(""); ok {
+		t.Errorf("parseRetryAfter(\"\") should not be ok")
+	}
+
+	future := time.Now().Add(5 * time.Second).UTC().Format(http.TimeFormat)
+	delay, ok = parseRetryAfter(future)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) should be ok", future)
+	}
+	if delay <= 0 || delay > 6*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want ~5s", future, delay)
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	if limiter := newRateLimiter(Config{RateLimit: 0}); limiter != nil {
+		t.Errorf("Expected nil limiter when RateLimit is unset, got %v", limiter)
+	}
+
+	limiter := newRateLimiter(Config{RateLimit: 5})
+	if limiter == nil {
+		t.Fatal("Expected a non-nil limiter when RateLimit is set")
+	}
+	if limiter.Limit() != 5 {
+		t.Errorf("Expected limit of 5, got %v", limiter.Limit())
+	}
+}
+
+func TestTestMethodBacksOffRateLimitOnRetryableStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL, MaxRetries: 1, RetryBaseDelay: time.Millisecond, RetryMaxDelay: 5 * time.Millisecond, Backoff: true}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	limiter := newRateLimiter(Config{RateLimit: 10})
+	results := make(map[string]Result)
+	resultsMutex := &sync.Mutex{}
+	logger := &Logger{Quiet: true}
+
+	testMethod(client, config, "GET", nil, nil, resultsMutex, results, logger, limiter, nil)
+
+	if limiter.Limit() >= 10 {
+		t.Errorf("Expected rate limit to have backed off below 10, got %v", limiter.Limit())
+	}
+}
+
+func TestTestMethodDoesNotDuplicateSeededCookies(t *testing.T) {
+	var gotCookieHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCookieHeader = r.Header.Get("Cookie")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL, Cookies: "session=abc123"}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	cookies, err := parseCookies(config.Cookies)
+	if err != nil {
+		t.Fatalf("Failed to parse cookies: %v", err)
+	}
+
+	results := make(map[string]Result)
+	resultsMutex := &sync.Mutex{}
+	logger := &Logger{Quiet: true}
+
+	testMethod(client, config, "GET", nil, cookies, resultsMutex, results, logger, nil, nil)
+
+	if count := strings.Count(gotCookieHeader, "session=abc123"); count != 1 {
+		t.Errorf("Expected cookie to appear once on the wire, got %q", gotCookieHeader)
+	}
+}
+
+func TestTestMethodDumpsTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	dumpFile := filepath.Join(tmpDir, "dump.txt")
+
+	config := Config{URL: server.URL, DumpFile: dumpFile}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	results := make(map[string]Result)
+	resultsMutex := &sync.Mutex{}
+	logger := &Logger{Quiet: true}
+
+	testMethod(client, config, "GET", nil, nil, resultsMutex, results, logger, nil, nil)
+
+	content, err := os.ReadFile(dumpFile)
+	if err != nil {
+		t.Fatalf("Failed to read dump file: %v", err)
+	}
+
+	got := string(content)
+	if !strings.Contains(got, "Method: GET") {
+		t.Errorf("Expected dump to contain method header, got %q", got)
+	}
+	if !strings.Contains(got, "HTTP/1.1 200") {
+		t.Errorf("Expected dump to contain the raw response status line, got %q", got)
+	}
+	if !strings.Contains(got, "ok") {
+		t.Errorf("Expected dump to contain the response body, got %q", got)
+	}
+	if !strings.Contains(got, "Accept-Encoding") {
+		t.Errorf("Expected dump to contain transport-added headers like Accept-Encoding, got %q", got)
+	}
+}
+
+func TestTestMethodRetriesOnTransientStatus(t *testing.T) {
+	var requestCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	config := Config{
+		URL:            server.URL,
+		MaxRetries:     2,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  5 * time.Millisecond,
+	}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	results := make(map[string]Result)
+	resultsMutex := &sync.Mutex{}
+	logger := &Logger{Quiet: true}
+
+	testMethod(client, config, "GET", nil, nil, resultsMutex, results, logger, nil, nil)
+
+	result, ok := results["GET"]
+	if !ok {
+		t.Fatalf("No result found for GET")
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("Expected final status 200 after retries, got %d", result.StatusCode)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Expected 3 attempts (2 failures + 1 success), got %d", result.Attempts)
+	}
+	if requestCount != 3 {
+		t.Errorf("Expected 3 requests to reach the server, got %d", requestCount)
+	}
+}
+
+func TestTestMethodWithTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL, Trace: true}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	results := make(map[string]Result)
+	resultsMutex := &sync.Mutex{}
+	logger := &Logger{Quiet: true}
+
+	testMethod(client, config, "GET", nil, nil, resultsMutex, results, logger, nil, nil)
+
+	result, ok := results["GET"]
+	if !ok {
+		t.Fatalf("No result found for GET")
+	}
+	if result.Timing == nil {
+		t.Fatalf("Expected Timing to be populated when Trace is enabled")
+	}
+	if result.Timing.Total <= 0 {
+		t.Errorf("Expected Timing.Total to be positive, got %v", result.Timing.Total)
+	}
+}
+
+func TestTimingMarshalJSON(t *testing.T) {
+	timing := Timing{
+		DNSLookup:    1 * time.Millisecond,
+		Connect:      2 * time.Millisecond,
+		TLSHandshake: 3 * time.Millisecond,
+		TTFB:         4 * time.Millisecond,
+		Total:        10 * time.Millisecond,
+	}
+
+	data, err := json.Marshal(timing)
+	if err != nil {
+		t.Fatalf("Failed to marshal Timing: %v", err)
+	}
+
+	var decoded map[string]float64
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal Timing JSON: %v", err)
+	}
+
+	want := map[string]float64{
+		"dns_lookup_ms":    1,
+		"connect_ms":       2,
+		"tls_handshake_ms": 3,
+		"ttfb_ms":          4,
+		"total_ms":         10,
+	}
+	for key, wantVal := range want {
+		if decoded[key] != wantVal {
+			t.Errorf("Expected %s=%v, got %v", key, wantVal, decoded[key])
+		}
+	}
+}
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"lowercases scheme and host", "HTTPS://Example.com/path", "https://example.com/path"},
+		{"drops fragment", "https://example.com/path#section", "https://example.com/path"},
+		{"unparseable returned unchanged", "://bad", "://bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonicalizeURL(tt.in); got != tt.want {
+				t.Errorf("canonicalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://example.com/next>; rel="next", <https://example.com/prev>; rel="prev"`
+	got := parseLinkHeader(header)
+	want := []string{"https://example.com/next", "https://example.com/prev"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d links, got %d (%v)", len(want), len(got), got)
+	}
+	for i, link := range want {
+		if got[i] != link {
+			t.Errorf("Expected link %d to be %q, got %q", i, link, got[i])
+		}
+	}
+}
+
+func TestDiscoverLinks(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{
+			"Location":     []string{"/next"},
+			"Link":         []string{`<https://example.com/api>; rel="self"`},
+			"Content-Type": []string{"text/html"},
+		},
+	}
+	body := []byte(`<html><body><a href="/page1">Page 1</a><a href="https://other.com/evil">Evil</a></body></html>`)
+
+	got := discoverLinks(resp, body, "https://example.com/start")
+
+	want := map[string]bool{
+		"https://example.com/next":  true,
+		"https://example.com/api":   true,
+		"https://example.com/page1": true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d discovered links, got %d (%v)", len(want), len(got), got)
+	}
+	for _, link := range got {
+		if !want[link] {
+			t.Errorf("Unexpected discovered link %q", link)
+		}
+	}
+}
+
+func TestProbeBypasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Forwarded-For") == "127.0.0.1" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("bypassed"))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	baseline := Result{StatusCode: http.StatusForbidden, Length: len("forbidden")}
+	results := probeBypasses(client, config, "GET", nil, baseline, defaultBypassMutations)
+
+	bypass, ok := results["header:x-forwarded-for"]
+	if !ok {
+		t.Fatalf("Expected header:x-forwarded-for to be reported as interesting, got %v", results)
+	}
+	if bypass.StatusCode != http.StatusOK {
+		t.Errorf("Expected bypassed status 200, got %d", bypass.StatusCode)
+	}
+
+	if _, ok := results["header:x-client-ip"]; ok {
+		t.Errorf("Expected header:x-client-ip to match baseline and be skipped, got %v", results["header:x-client-ip"])
+	}
+}
+
+func TestBypassPathMutationsDifferFromInput(t *testing.T) {
+	for _, mutation := range defaultBypassMutations {
+		if mutation.path == nil {
+			continue
+		}
+		for _, p := range []string{"/foo", "/foo/bar"} {
+			if mutated := mutation.path(p); mutated == p {
+				t.Errorf("Mutation %q produced no change for input %q", mutation.id, p)
+			}
+		}
+	}
+}
+
+func TestBypassDoubleSlashMutation(t *testing.T) {
+	for _, mutation := range defaultBypassMutations {
+		if mutation.id != "path:double-slash" {
+			continue
+		}
+		if got := mutation.path("/foo"); got != "//foo" {
+			t.Errorf("Expected path:double-slash to turn %q into %q, got %q", "/foo", "//foo", got)
+		}
+		return
+	}
+	t.Fatal("path:double-slash mutation not found")
+}
+
+func TestBypassMethodOverrideMutation(t *testing.T) {
+	var gotMethod, gotOverride string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotOverride = r.Header.Get("X-HTTP-Method-Override")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := Config{URL: server.URL}
+	client, err := buildHTTPClient(config)
+	if err != nil {
+		t.Fatalf("Failed to build HTTP client: %v", err)
+	}
+
+	var mutation bypassMutation
+	for _, m := range defaultBypassMutations {
+		if m.id == "header:x-http-method-override" {
+			mutation = m
+		}
+	}
+
+	baseline := Result{StatusCode: http.StatusForbidden}
+	probeBypasses(client, config, http.MethodDelete, nil, baseline, []bypassMutation{mutation})
+
+	if gotMethod != http.MethodGet {
+		t.Errorf("Expected request to be sent as GET, got %s", gotMethod)
+	}
+	if gotOverride != http.MethodDelete {
+		t.Errorf("Expected X-HTTP-Method-Override to carry DELETE, got %q", gotOverride)
+	}
+}
+
+func TestLoadBypassMutationsFromPayloadFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	payloadFile := filepath.Join(tmpDir, "payloads.yaml")
+	payloadYAML := `
+- id: custom:header
+  header: X-Custom-Bypass
+  value: "1"
+- id: custom:path
+  path_suffix: /custom
+`
+	if err := os.WriteFile(payloadFile, []byte(payloadYAML), 0644); err != nil {
+		t.Fatalf("Failed to write payload file: %v", err)
+	}
+
+	mutations, err := loadBypassMutations(payloadFile)
+	if err != nil {
+		t.Fatalf("loadBypassMutations returned error: %v", err)
+	}
+	if len(mutations) != len(defaultBypassMutations)+2 {
+		t.Fatalf("Expected %d mutations, got %d", len(defaultBypassMutations)+2, len(mutations))
+	}
+
+	for _, m := range mutations {
+		if m.id == "custom:path" {
+			if got := m.path("/foo"); got != "/foo/custom" {
+				t.Errorf("Expected custom:path to append /custom, got %q", got)
+			}
+		}
+	}
+}
+
 func TestIntegrationWithMockServer(t *testing.T) {
 	// Create a mock server to test against
 	allowedMethods := []string{"GET", "POST", "OPTIONS", "HEAD"}
@@ -509,7 +1318,7 @@ func TestIntegrationWithMockServer(t *testing.T) {
 		wg.Add(1)
 		go func(method string) {
 			defer wg.Done()
-			testMethod(client, config.URL, method, nil, nil, resultsMutex, results, logger)
+			testMethod(client, config, method, nil, nil, resultsMutex, results, logger, nil, nil)
 		}(method)
 	}
 